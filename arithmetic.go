@@ -0,0 +1,240 @@
+package pbmoney
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+)
+
+// Difference subtracts r from l. It has the same validity and currency-match
+// requirements as Sum.
+func Difference(l, r *pb.Money) (*pb.Money, error) {
+	return Sum(l, Negate(r))
+}
+
+// Compare returns -1, 0 or +1 depending on whether l is less than, equal to,
+// or greater than r. It returns an error, rather than panicking, if l and r
+// are not valid or do not share a currency code, mirroring the validation
+// Sum performs and matching the rest of this package's error-return
+// convention.
+func Compare(l, r *pb.Money) (int, error) {
+	if !IsValid(l) || !IsValid(r) {
+		return 0, ErrInvalidValue
+	}
+	if l.GetCurrencyCode() != r.GetCurrencyCode() {
+		return 0, ErrMismatchingCurrency
+	}
+	ln, err := totalNanos(l)
+	if err != nil {
+		return 0, err
+	}
+	rn, err := totalNanos(r)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case ln < rn:
+		return -1, nil
+	case ln > rn:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Min returns whichever of l and r compares lower.
+func Min(l, r *pb.Money) (*pb.Money, error) {
+	cmp, err := Compare(l, r)
+	if err != nil {
+		return nil, err
+	}
+	if cmp <= 0 {
+		return l, nil
+	}
+	return r, nil
+}
+
+// Max returns whichever of l and r compares higher.
+func Max(l, r *pb.Money) (*pb.Money, error) {
+	cmp, err := Compare(l, r)
+	if err != nil {
+		return nil, err
+	}
+	if cmp >= 0 {
+		return l, nil
+	}
+	return r, nil
+}
+
+// Abs returns the same amount with the sign cleared. It routes through
+// totalNanos/fromTotalNanos (rather than negating Units/Nanos directly) so
+// the existing overflow guard catches the one case a naive negation would
+// get wrong: m.Units == math.MinInt64, whose negation doesn't fit in an
+// int64 and would otherwise wrap right back to itself.
+func Abs(m *pb.Money) (*pb.Money, error) {
+	if !IsValid(m) {
+		return nil, ErrInvalidValue
+	}
+	total, err := totalNanos(m)
+	if err != nil {
+		return nil, err
+	}
+	if total == math.MinInt64 {
+		return nil, ErrOverflow
+	}
+	if total < 0 {
+		total = -total
+	}
+	return fromTotalNanos(total, m.GetCurrencyCode()), nil
+}
+
+// Allocate splits m into len(ratios) parts, weighted by ratios, without
+// losing any nanos to rounding: the total nanos are divided by weight and
+// any leftover nanos (from integer truncation) are handed out one at a time,
+// largest-remainder first, so summing the result always reproduces m
+// exactly.
+func Allocate(m *pb.Money, ratios []int64) ([]*pb.Money, error) {
+	if !IsValid(m) {
+		return nil, ErrInvalidValue
+	}
+	if len(ratios) == 0 {
+		return nil, errors.New("pbmoney: Allocate requires at least one ratio")
+	}
+	var totalRatio int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, errors.New("pbmoney: Allocate ratios must be non-negative")
+		}
+		totalRatio += r
+	}
+	if totalRatio == 0 {
+		return nil, errors.New("pbmoney: Allocate ratios must sum to more than zero")
+	}
+
+	total, err := totalNanos(m)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*pb.Money, len(ratios))
+	remainders := make([]int64, len(ratios))
+	var allocated int64
+	for i, r := range ratios {
+		share, rem, err := mulDivMod(total, r, totalRatio)
+		if err != nil {
+			return nil, err
+		}
+		remainders[i] = rem
+		allocated += share
+		out[i] = fromTotalNanos(share, m.GetCurrencyCode())
+	}
+
+	// Distribute whatever is left over, largest remainder first, one nano at
+	// a time, to the buckets that were truncated the most.
+	leftover := total - allocated
+	step := int64(1)
+	if leftover < 0 {
+		step = -1
+	}
+	for leftover != 0 {
+		best := -1
+		for i, rem := range remainders {
+			if best == -1 || rem*step > remainders[best]*step {
+				best = i
+			}
+		}
+		bestTotal, err := totalNanos(out[best])
+		if err != nil {
+			return nil, err
+		}
+		out[best] = fromTotalNanos(bestTotal+step, m.GetCurrencyCode())
+		remainders[best] = 0
+		leftover -= step
+	}
+
+	return out, nil
+}
+
+// RoundingMode selects how Round resolves a value that falls exactly between
+// two multiples of the target exponent.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds 0.5 away from zero (the common "schoolbook" mode).
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds 0.5 to the nearest even digit ("banker's rounding").
+	RoundHalfEven
+	// RoundDown truncates towards zero.
+	RoundDown
+	// RoundUp rounds away from zero whenever there's any remainder.
+	RoundUp
+	// RoundHalfDown rounds 0.5 towards zero.
+	RoundHalfDown
+)
+
+// Round quantizes m to exp fractional decimal digits (e.g. exp=-2 rounds to
+// whole cents), using mode to resolve values exactly halfway between two
+// multiples. exp must be in [-9, 0]; -9 is a no-op since nanos are already
+// that precise.
+func Round(m *pb.Money, exp int32, mode RoundingMode) (*pb.Money, error) {
+	if !IsValid(m) {
+		return nil, ErrInvalidValue
+	}
+	if exp < -9 || exp > 0 {
+		return nil, fmt.Errorf("pbmoney: Round exponent %d out of range [-9, 0]", exp)
+	}
+
+	scale := int64(1)
+	for i := int32(0); i < 9+exp; i++ {
+		scale *= 10
+	}
+
+	total, err := totalNanos(m)
+	if err != nil {
+		return nil, err
+	}
+	quotient, remainder := total/scale, total%scale
+
+	roundUp := false
+	switch mode {
+	case RoundDown:
+		roundUp = false
+	case RoundUp:
+		roundUp = remainder != 0
+	case RoundHalfUp:
+		roundUp = 2*abs64(remainder) >= scale
+	case RoundHalfDown:
+		roundUp = 2*abs64(remainder) > scale
+	case RoundHalfEven:
+		switch d := 2 * abs64(remainder); {
+		case d > scale:
+			roundUp = true
+		case d == scale:
+			roundUp = quotient%2 != 0
+		}
+	default:
+		return nil, fmt.Errorf("pbmoney: unknown RoundingMode %d", mode)
+	}
+
+	if roundUp {
+		if remainder >= 0 {
+			quotient++
+		} else {
+			quotient--
+		}
+	}
+
+	rounded, err := mulDiv(quotient, scale, 1)
+	if err != nil {
+		return nil, err
+	}
+	return fromTotalNanos(rounded, m.GetCurrencyCode()), nil
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,145 @@
+package pbmoney
+
+import (
+	"errors"
+	"sort"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+)
+
+// ErrNegativeAmount is returned by Add and Sub if the result would leave a
+// negative amount in the bag.
+var ErrNegativeAmount = errors.New("pbmoney: Bag amounts must not be negative")
+
+// Bag (also thought of as a Wallet or a collection of Coins) holds at most
+// one *pb.Money per currency code. It mirrors the safety guarantees of
+// Cosmos-SDK's Coins type: no duplicate denominations, no negative amounts,
+// and iteration always happens in currency-code order regardless of
+// insertion order.
+type Bag struct {
+	amounts map[string]*pb.Money
+}
+
+// NewBag returns an empty Bag.
+func NewBag() *Bag {
+	return &Bag{amounts: map[string]*pb.Money{}}
+}
+
+// Add returns a new Bag with m added to whatever amount b already holds for
+// m's currency. It returns an error if m is invalid or if the resulting
+// amount would be negative.
+func (b *Bag) Add(m *pb.Money) (*Bag, error) {
+	if !IsValid(m) {
+		return nil, ErrInvalidValue
+	}
+	out := b.clone()
+	existing, ok := out.amounts[m.GetCurrencyCode()]
+	if !ok {
+		if IsNegative(m) {
+			return nil, ErrNegativeAmount
+		}
+		out.amounts[m.GetCurrencyCode()] = m
+		return out, nil
+	}
+	sum, err := Sum(existing, m)
+	if err != nil {
+		return nil, err
+	}
+	if IsNegative(sum) {
+		return nil, ErrNegativeAmount
+	}
+	out.amounts[m.GetCurrencyCode()] = sum
+	return out, nil
+}
+
+// Sub returns a new Bag with m subtracted from whatever amount b already
+// holds for m's currency (treating a missing currency as zero). It returns
+// ErrNegativeAmount if the result would be negative.
+func (b *Bag) Sub(m *pb.Money) (*Bag, error) {
+	if !IsValid(m) {
+		return nil, ErrInvalidValue
+	}
+	return b.Add(Negate(m))
+}
+
+// IsZero returns true if every amount held in the bag is zero (an empty bag
+// is zero).
+func (b *Bag) IsZero() bool {
+	for _, m := range b.amounts {
+		if !IsZero(m) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAnyNegative returns true if any amount held in the bag is negative.
+func (b *Bag) IsAnyNegative() bool {
+	for _, m := range b.amounts {
+		if IsNegative(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns true if the bag holds a (possibly zero) amount for
+// currencyCode.
+func (b *Bag) Contains(currencyCode string) bool {
+	_, ok := b.amounts[currencyCode]
+	return ok
+}
+
+// Get returns the amount held for currencyCode, or false if the bag doesn't
+// hold that currency.
+func (b *Bag) Get(currencyCode string) (*pb.Money, bool) {
+	m, ok := b.amounts[currencyCode]
+	return m, ok
+}
+
+// Currencies returns the currency codes held in the bag, in sorted order.
+func (b *Bag) Currencies() []string {
+	codes := make([]string, 0, len(b.amounts))
+	for code := range b.amounts {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Entries returns the amounts held in the bag, ordered by currency code.
+func (b *Bag) Entries() []*pb.Money {
+	codes := b.Currencies()
+	out := make([]*pb.Money, len(codes))
+	for i, code := range codes {
+		out[i] = b.amounts[code]
+	}
+	return out
+}
+
+// Intersect returns a new Bag holding, for every currency present in both b
+// and other, whichever of the two amounts is lower (per Compare).
+// Currencies present in only one of the bags are omitted.
+func (b *Bag) Intersect(other *Bag) (*Bag, error) {
+	out := NewBag()
+	for code, m := range b.amounts {
+		om, ok := other.amounts[code]
+		if !ok {
+			continue
+		}
+		lower, err := Min(m, om)
+		if err != nil {
+			return nil, err
+		}
+		out.amounts[code] = lower
+	}
+	return out, nil
+}
+
+func (b *Bag) clone() *Bag {
+	out := &Bag{amounts: make(map[string]*pb.Money, len(b.amounts))}
+	for code, m := range b.amounts {
+		out.amounts[code] = m
+	}
+	return out
+}
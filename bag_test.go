@@ -0,0 +1,161 @@
+package pbmoney
+
+import "testing"
+
+func TestBagAdd(t *testing.T) {
+	b := NewBag()
+	b, err := b.Add(mmc(10, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Add(10 USD): %v", err)
+	}
+	b, err = b.Add(mmc(5, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Add(5 USD): %v", err)
+	}
+	b, err = b.Add(mmc(2, 0, "EUR"))
+	if err != nil {
+		t.Fatalf("Add(2 EUR): %v", err)
+	}
+
+	got, ok := b.Get("USD")
+	if !ok || !AreEquals(got, mmc(15, 0, "USD")) {
+		t.Errorf("Get(USD) = %v, %v, want %v, true", got, ok, mmc(15, 0, "USD"))
+	}
+	got, ok = b.Get("EUR")
+	if !ok || !AreEquals(got, mmc(2, 0, "EUR")) {
+		t.Errorf("Get(EUR) = %v, %v, want %v, true", got, ok, mmc(2, 0, "EUR"))
+	}
+	if want := []string{"EUR", "USD"}; !stringSlicesEqual(b.Currencies(), want) {
+		t.Errorf("Currencies() = %v, want %v", b.Currencies(), want)
+	}
+}
+
+func TestBagAddRejectsInvalidAndNegativeResults(t *testing.T) {
+	b := NewBag()
+	if _, err := b.Add(mm(1, -1)); err != ErrInvalidValue {
+		t.Errorf("Add(invalid): err = %v, want ErrInvalidValue", err)
+	}
+	if _, err := b.Add(mmc(-1, 0, "USD")); err != ErrNegativeAmount {
+		t.Errorf("Add(-1 USD) on empty bag: err = %v, want ErrNegativeAmount", err)
+	}
+
+	b, err := b.Add(mmc(5, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Add(5 USD): %v", err)
+	}
+	if _, err := b.Add(mmc(-10, 0, "USD")); err != ErrNegativeAmount {
+		t.Errorf("Add(-10 USD) against 5 USD: err = %v, want ErrNegativeAmount", err)
+	}
+}
+
+func TestBagAddDoesNotMutateOriginal(t *testing.T) {
+	b := NewBag()
+	b, err := b.Add(mmc(5, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Add(5 USD): %v", err)
+	}
+	if _, err := b.Add(mmc(1, 0, "USD")); err != nil {
+		t.Fatalf("Add(1 USD): %v", err)
+	}
+	got, _ := b.Get("USD")
+	if !AreEquals(got, mmc(5, 0, "USD")) {
+		t.Errorf("original bag mutated: Get(USD) = %v, want unchanged %v", got, mmc(5, 0, "USD"))
+	}
+}
+
+func TestBagSub(t *testing.T) {
+	b := NewBag()
+	b, err := b.Add(mmc(10, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Add(10 USD): %v", err)
+	}
+	b, err = b.Sub(mmc(4, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Sub(4 USD): %v", err)
+	}
+	got, _ := b.Get("USD")
+	if !AreEquals(got, mmc(6, 0, "USD")) {
+		t.Errorf("Get(USD) = %v, want %v", got, mmc(6, 0, "USD"))
+	}
+
+	if _, err := b.Sub(mmc(100, 0, "USD")); err != ErrNegativeAmount {
+		t.Errorf("Sub(100 USD) against 6 USD: err = %v, want ErrNegativeAmount", err)
+	}
+}
+
+func TestBagIsZeroAndIsAnyNegative(t *testing.T) {
+	b := NewBag()
+	if !b.IsZero() {
+		t.Error("empty Bag.IsZero() = false, want true")
+	}
+	if b.IsAnyNegative() {
+		t.Error("empty Bag.IsAnyNegative() = true, want false")
+	}
+
+	b, err := b.Add(mmc(0, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Add(0 USD): %v", err)
+	}
+	if !b.IsZero() {
+		t.Error("Bag holding only 0 USD: IsZero() = false, want true")
+	}
+
+	b, err = b.Add(mmc(1, 0, "EUR"))
+	if err != nil {
+		t.Fatalf("Add(1 EUR): %v", err)
+	}
+	if b.IsZero() {
+		t.Error("Bag holding 1 EUR: IsZero() = true, want false")
+	}
+}
+
+func TestBagContains(t *testing.T) {
+	b := NewBag()
+	if b.Contains("USD") {
+		t.Error("empty Bag.Contains(USD) = true, want false")
+	}
+	b, err := b.Add(mmc(1, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Add(1 USD): %v", err)
+	}
+	if !b.Contains("USD") {
+		t.Error("Bag.Contains(USD) = false, want true")
+	}
+	if b.Contains("EUR") {
+		t.Error("Bag.Contains(EUR) = true, want false")
+	}
+}
+
+func TestBagIntersect(t *testing.T) {
+	a := NewBag()
+	a, _ = a.Add(mmc(10, 0, "USD"))
+	a, _ = a.Add(mmc(5, 0, "EUR"))
+
+	b := NewBag()
+	b, _ = b.Add(mmc(3, 0, "USD"))
+	b, _ = b.Add(mmc(20, 0, "GBP"))
+
+	out, err := a.Intersect(b)
+	if err != nil {
+		t.Fatalf("Intersect: %v", err)
+	}
+	if want := []string{"USD"}; !stringSlicesEqual(out.Currencies(), want) {
+		t.Fatalf("Intersect Currencies() = %v, want %v", out.Currencies(), want)
+	}
+	got, _ := out.Get("USD")
+	if !AreEquals(got, mmc(3, 0, "USD")) {
+		t.Errorf("Intersect Get(USD) = %v, want %v (the lower of the two)", got, mmc(3, 0, "USD"))
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package pbmoney
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+)
+
+var (
+	// ErrDivideByZero is returned by Divide and friends when the scalar is zero.
+	ErrDivideByZero = errors.New("pbmoney: division by zero")
+
+	// ErrOverflow is returned when a fixed-point multiply/divide does not fit
+	// in an int64 count of nanos.
+	ErrOverflow = errors.New("pbmoney: result overflows int64 nanos")
+)
+
+// Multiply scales m by a fixed-point scalar expressed in nanos (i.e.
+// scalarNanos/1e9), exact to 9 fractional digits. It operates on the full
+// units*1e9+nanos value via a 128-bit intermediate product, so unlike the
+// old micros-based fast path it never silently truncates; it returns
+// ErrOverflow instead of wrapping if the product doesn't fit in an int64
+// nano count.
+func Multiply(m *pb.Money, scalarNanos int64) (*pb.Money, error) {
+	if !IsValid(m) {
+		return nil, ErrInvalidValue
+	}
+	mNanos, err := totalNanos(m)
+	if err != nil {
+		return nil, err
+	}
+	total, err := mulDiv(mNanos, scalarNanos, nanosMod)
+	if err != nil {
+		return nil, err
+	}
+	return fromTotalNanos(total, m.GetCurrencyCode()), nil
+}
+
+// Divide scales m by the reciprocal of a fixed-point scalar expressed in
+// nanos (i.e. it divides m by scalarNanos/1e9), exact to 9 fractional
+// digits. See Multiply for the precision and overflow guarantees.
+func Divide(m *pb.Money, scalarNanos int64) (*pb.Money, error) {
+	if !IsValid(m) {
+		return nil, ErrInvalidValue
+	}
+	if scalarNanos == 0 {
+		return nil, ErrDivideByZero
+	}
+	mNanos, err := totalNanos(m)
+	if err != nil {
+		return nil, err
+	}
+	total, err := mulDiv(mNanos, nanosMod, scalarNanos)
+	if err != nil {
+		return nil, err
+	}
+	return fromTotalNanos(total, m.GetCurrencyCode()), nil
+}
+
+// maxInt64Float and minInt64Float bound the range of float64 values that
+// convert to an in-range int64; math.MaxInt64 itself isn't exactly
+// representable as a float64, so the upper bound is one past it (2^63).
+// Converting a float64 outside [minInt64Float, maxInt64Float) to int64 is
+// implementation-defined in Go (in practice it clamps to math.MinInt64 on
+// amd64/arm64), so this range must be checked before the cast rather than
+// left to the conversion.
+const (
+	maxInt64Float = 1 << 63
+	minInt64Float = -maxInt64Float
+)
+
+// MultiplyFloat scales m by scalar, converting scalar to a nanos-scaled
+// fixed-point value before handing off to Multiply. It returns ErrOverflow,
+// rather than silently wrapping, if scalar*1e9 doesn't fit in an int64.
+func MultiplyFloat(m *pb.Money, scalar float64) (*pb.Money, error) {
+	if math.IsNaN(scalar) || math.IsInf(scalar, 0) {
+		return nil, fmt.Errorf("pbmoney: invalid scalar %v", scalar)
+	}
+	scaled := math.Round(scalar * nanosMod)
+	if scaled < minInt64Float || scaled >= maxInt64Float {
+		return nil, ErrOverflow
+	}
+	return Multiply(m, int64(scaled))
+}
+
+// MultiplyRat scales m by an arbitrary-precision rational scalar, using
+// math/big throughout so the result is exact (beyond truncating to whole
+// nanos at the end) regardless of how scalar's numerator and denominator
+// are sized.
+func MultiplyRat(m *pb.Money, scalar *big.Rat) (*pb.Money, error) {
+	if !IsValid(m) {
+		return nil, ErrInvalidValue
+	}
+	mNanos, err := totalNanos(m)
+	if err != nil {
+		return nil, err
+	}
+	total := big.NewInt(mNanos)
+	total.Mul(total, scalar.Num())
+	total.Quo(total, scalar.Denom())
+	if !total.IsInt64() {
+		return nil, ErrOverflow
+	}
+	return fromTotalNanos(total.Int64(), m.GetCurrencyCode()), nil
+}
+
+// mulDiv computes total*num/den exactly, via a 128-bit intermediate
+// product, returning ErrOverflow if the quotient doesn't fit in an int64
+// and ErrDivideByZero if den is zero.
+func mulDiv(total, num, den int64) (int64, error) {
+	q, _, err := mulDivMod(total, num, den)
+	return q, err
+}
+
+// mulDivMod is mulDiv, but also returns the remainder of total*num/den (with
+// the same sign as total*num) instead of discarding it. Allocate uses this
+// to rank buckets by how much they were truncated without ever forming
+// total*num as a plain int64 multiply.
+func mulDivMod(total, num, den int64) (q, rem int64, err error) {
+	if den == 0 {
+		return 0, 0, ErrDivideByZero
+	}
+	prodNeg := (total < 0) != (num < 0)
+	neg := prodNeg
+	if den < 0 {
+		neg = !neg
+		den = -den
+	}
+
+	hi, lo := bits.Mul64(uint64(abs64(total)), uint64(abs64(num)))
+	if hi >= uint64(den) {
+		return 0, 0, ErrOverflow
+	}
+	qu, ru := bits.Div64(hi, lo, uint64(den))
+	if qu > math.MaxInt64 {
+		return 0, 0, ErrOverflow
+	}
+
+	q, rem = int64(qu), int64(ru)
+	if neg {
+		q = -q
+	}
+	if prodNeg {
+		rem = -rem
+	}
+	return q, rem, nil
+}
+
+// MultipleFast multiplies two money amounts together, treating r as a
+// nanos-scaled fixed-point scalar. It panics on overflow; use Multiply
+// directly if you need to handle that case.
+func MultipleFast(l, r *pb.Money) *pb.Money {
+	rNanos, err := totalNanos(r)
+	if err != nil {
+		panic(err)
+	}
+	return Must(Multiply(l, rNanos))
+}
+
+// DivideFast divides l by r, treating r as a nanos-scaled fixed-point
+// scalar. It panics on overflow or division by zero; use Divide directly if
+// you need to handle those cases.
+func DivideFast(l, r *pb.Money) *pb.Money {
+	rNanos, err := totalNanos(r)
+	if err != nil {
+		panic(err)
+	}
+	return Must(Divide(l, rNanos))
+}
+
+// DivideFastInt divides l by the plain integer r. It panics on overflow or
+// division by zero; use Divide directly if you need to handle those cases.
+func DivideFastInt(l *pb.Money, r int64) *pb.Money {
+	lNanos, err := totalNanos(l)
+	if err != nil {
+		panic(err)
+	}
+	total, err := mulDiv(lNanos, 1, r)
+	if err != nil {
+		panic(err)
+	}
+	return fromTotalNanos(total, l.GetCurrencyCode())
+}
+
+// MultipleFastInt multiplies l by the plain integer r. It panics on
+// overflow; use Multiply directly if you need to handle that case.
+func MultipleFastInt(l *pb.Money, r int64) *pb.Money {
+	lNanos, err := totalNanos(l)
+	if err != nil {
+		panic(err)
+	}
+	total, err := mulDiv(lNanos, r, 1)
+	if err != nil {
+		panic(err)
+	}
+	return fromTotalNanos(total, l.GetCurrencyCode())
+}
@@ -0,0 +1,249 @@
+package pbmoney
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+)
+
+// SymbolPlacement controls where Format puts the currency symbol relative
+// to the number.
+type SymbolPlacement int
+
+const (
+	// SymbolNone omits the currency symbol entirely.
+	SymbolNone SymbolPlacement = iota
+	// SymbolPrefix places the symbol before the number, e.g. "$1.00".
+	SymbolPrefix
+	// SymbolSuffix places the symbol after the number, e.g. "1.00$".
+	SymbolSuffix
+)
+
+// NegativeStyle controls how Format renders negative amounts.
+type NegativeStyle int
+
+const (
+	// NegativeSign renders negative amounts with a leading "-".
+	NegativeSign NegativeStyle = iota
+	// NegativeParens renders negative amounts wrapped in parentheses, e.g.
+	// "($1.00)", with no leading "-".
+	NegativeParens
+)
+
+// FormatOptions controls how Format renders a *pb.Money as a string.
+type FormatOptions struct {
+	// MinFractionDigits is the minimum number of digits to print after the
+	// decimal separator, padding with zeros if needed. A nil pointer (the
+	// zero value) defaults to the currency's ISO 4217 exponent (via
+	// CurrencyExponent); a pointer to 0 explicitly requests no fraction
+	// digits even for a currency whose exponent isn't 0. Use
+	// DefaultFormatOptions to start from explicit defaults.
+	MinFractionDigits *int
+	// MaxFractionDigits is the maximum number of digits to print after the
+	// decimal separator; the amount is rounded (half-up) to this many
+	// digits first. Defaults the same way as MinFractionDigits.
+	MaxFractionDigits *int
+
+	// ThousandsSeparator is inserted every three digits of the integer part.
+	// Leave empty to disable grouping.
+	ThousandsSeparator string
+	// DecimalSeparator separates the integer and fractional parts. Defaults
+	// to "." if left empty.
+	DecimalSeparator string
+
+	// CurrencySymbol is printed according to SymbolPlacement. Leave empty
+	// (with SymbolNone) to print no symbol.
+	CurrencySymbol  string
+	SymbolPlacement SymbolPlacement
+	// SymbolSpace inserts a space between the symbol and the number.
+	SymbolSpace bool
+
+	NegativeStyle NegativeStyle
+}
+
+// DefaultFormatOptions returns FormatOptions with sensible defaults for
+// currencyCode: its ISO 4217 exponent for both fraction-digit bounds, a
+// comma thousands separator, a dot decimal separator, and a leading "-" for
+// negative amounts.
+func DefaultFormatOptions(currencyCode string) FormatOptions {
+	exp := CurrencyExponent(currencyCode)
+	return FormatOptions{
+		MinFractionDigits:  &exp,
+		MaxFractionDigits:  &exp,
+		ThousandsSeparator: ",",
+		DecimalSeparator:   ".",
+	}
+}
+
+// Format renders m as a string per opts. MinFractionDigits/MaxFractionDigits
+// left nil (the FormatOptions{} default) fall back to the currency's ISO
+// 4217 exponent; DecimalSeparator left empty falls back to "."; there is no
+// grouping and no currency symbol unless requested.
+func Format(m *pb.Money, opts FormatOptions) (string, error) {
+	if !IsValid(m) {
+		return "", ErrInvalidValue
+	}
+
+	exp := CurrencyExponent(m.GetCurrencyCode())
+	minDigits, maxDigits := exp, exp
+	if opts.MinFractionDigits != nil {
+		minDigits = *opts.MinFractionDigits
+	}
+	if opts.MaxFractionDigits != nil {
+		maxDigits = *opts.MaxFractionDigits
+	}
+	if maxDigits < minDigits {
+		return "", fmt.Errorf("pbmoney: MaxFractionDigits %d is less than MinFractionDigits %d", maxDigits, minDigits)
+	}
+
+	decimalSep := opts.DecimalSeparator
+	if decimalSep == "" {
+		decimalSep = "."
+	}
+
+	rounded, err := Round(m, int32(-maxDigits), RoundHalfUp)
+	if err != nil {
+		return "", err
+	}
+
+	negative := IsNegative(rounded)
+	abs, err := Abs(rounded)
+	if err != nil {
+		return "", err
+	}
+	intPart := strconv.FormatInt(abs.GetUnits(), 10)
+	fracPart := fmt.Sprintf("%09d", abs.GetNanos())[:maxDigits]
+	for len(fracPart) > minDigits && strings.HasSuffix(fracPart, "0") {
+		fracPart = fracPart[:len(fracPart)-1]
+	}
+
+	if opts.ThousandsSeparator != "" {
+		intPart = groupThousands(intPart, opts.ThousandsSeparator)
+	}
+
+	number := intPart
+	if len(fracPart) > 0 {
+		number = intPart + decimalSep + fracPart
+	}
+
+	if opts.CurrencySymbol != "" {
+		sep := ""
+		if opts.SymbolSpace {
+			sep = " "
+		}
+		switch opts.SymbolPlacement {
+		case SymbolPrefix:
+			number = opts.CurrencySymbol + sep + number
+		case SymbolSuffix:
+			number = number + sep + opts.CurrencySymbol
+		}
+	}
+
+	if !negative {
+		return number, nil
+	}
+	if opts.NegativeStyle == NegativeParens {
+		return "(" + number + ")", nil
+	}
+	return "-" + number, nil
+}
+
+// groupThousands inserts sep every three digits of the (unsigned) integer
+// string digits, counting from the right.
+func groupThousands(digits, sep string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	lead := len(digits) % 3
+	if lead == 0 {
+		lead = 3
+	}
+	b.WriteString(digits[:lead])
+	for i := lead; i < len(digits); i += 3 {
+		b.WriteString(sep)
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// Parse parses s as an amount in currency, accepting an optional leading
+// "-" or "(...)" for negative values, an optional thousands separator of
+// "," and a "." decimal separator. By default it rejects strings whose
+// fractional part is longer than currency's ISO 4217 exponent; pass a
+// RoundingMode to round to that exponent with it instead. Strings with more
+// than 9 fractional digits are rejected regardless of mode.
+func Parse(s, currency string, mode ...RoundingMode) (*pb.Money, error) {
+	return parseAtExponent(s, currency, CurrencyExponent(currency), mode...)
+}
+
+// parseAtExponent is Parse, but quantizing to exp fractional digits instead
+// of always using currency's ISO 4217 exponent. It backs Parse itself (with
+// exp set to CurrencyExponent(currency)) and SQLNumeric's Scan, which needs
+// to parse at full nanos precision (exp=9) to round-trip what Value wrote.
+func parseAtExponent(s, currency string, exp int, mode ...RoundingMode) (*pb.Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("pbmoney: cannot parse empty string")
+	}
+
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	} else if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	}
+	if strings.HasPrefix(s, "-") {
+		return nil, fmt.Errorf("pbmoney: invalid amount %q", s)
+	}
+
+	s = strings.ReplaceAll(s, ",", "")
+	if s == "" {
+		return nil, fmt.Errorf("pbmoney: cannot parse empty string")
+	}
+
+	intStr, fracStr := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intStr, fracStr = s[:i], s[i+1:]
+	}
+	if intStr == "" {
+		intStr = "0"
+	}
+
+	units, err := strconv.ParseInt(intStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("pbmoney: invalid amount %q: %w", s, err)
+	}
+	if len(fracStr) > 9 {
+		return nil, fmt.Errorf("pbmoney: %q has more than 9 fractional digits", s)
+	}
+	for _, c := range fracStr {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("pbmoney: invalid amount %q", s)
+		}
+	}
+
+	needsRounding := len(fracStr) > exp
+	if needsRounding && len(mode) == 0 {
+		return nil, fmt.Errorf("pbmoney: %q has more fractional digits than %s allows (%d)", s, currency, exp)
+	}
+
+	nanos64, err := strconv.ParseInt(fracStr+strings.Repeat("0", 9-len(fracStr)), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("pbmoney: invalid amount %q: %w", s, err)
+	}
+	nanos := int32(nanos64)
+	if negative {
+		units, nanos = -units, -nanos
+	}
+	parsed := &pb.Money{Units: units, Nanos: nanos, CurrencyCode: currency}
+
+	if !needsRounding {
+		return parsed, nil
+	}
+	return Round(parsed, int32(-exp), mode[0])
+}
@@ -0,0 +1,46 @@
+package pbmoney
+
+import "testing"
+
+// TestFormatExplicitZeroFractionDigits asserts that an explicit
+// MinFractionDigits/MaxFractionDigits of 0 is honored even for a currency
+// whose ISO 4217 exponent isn't 0, rather than being mistaken for "left at
+// the zero value" and silently defaulted back to the currency's exponent.
+func TestFormatExplicitZeroFractionDigits(t *testing.T) {
+	zero := 0
+	m := mmc(1234, 560000000, "USD")
+	got, err := Format(m, FormatOptions{MinFractionDigits: &zero, MaxFractionDigits: &zero})
+	if err != nil {
+		t.Fatalf("Format(%v) error: %v", m, err)
+	}
+	if want := "1235"; got != want {
+		t.Errorf("Format(%v, {Min: 0, Max: 0}) = %q, want %q", m, got, want)
+	}
+}
+
+// TestFormatDefaultsToCurrencyExponent asserts that FormatOptions{} (no
+// fraction-digit fields set) still defaults to the currency's ISO 4217
+// exponent, since plenty of call sites rely on that zero-value behavior.
+func TestFormatDefaultsToCurrencyExponent(t *testing.T) {
+	m := mmc(1234, 560000000, "USD")
+	got, err := Format(m, FormatOptions{})
+	if err != nil {
+		t.Fatalf("Format(%v) error: %v", m, err)
+	}
+	if want := "1234.56"; got != want {
+		t.Errorf("Format(%v, {}) = %q, want %q", m, got, want)
+	}
+}
+
+// TestParseRejectsDoubleSign asserts that a redundant negative marker
+// (a second leading "-" inside parens, or doubled leading "-") is rejected
+// rather than accepted and canceled out: Parse used to strip one "-"/paren
+// pair and then let strconv.ParseInt silently absorb a second "-", so
+// "--5" and "(-5)" both parsed as +5 instead of erroring.
+func TestParseRejectsDoubleSign(t *testing.T) {
+	for _, s := range []string{"--5", "(-5)"} {
+		if _, err := Parse(s, "USD"); err == nil {
+			t.Errorf("Parse(%q, USD): err = nil, want an error", s)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package pbmoney
+
+import (
+	"testing"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+
+	"github.com/Kashoo/pb-money/pbmoneytest"
+)
+
+// clampMoney builds a valid *pb.Money out of arbitrary fuzzed/quick-checked
+// inputs, clamping nanos into range and fixing up its sign to match units.
+func clampMoney(units int64, nanos int32) *pb.Money {
+	if nanos > nanosMax {
+		nanos = nanosMax
+	}
+	if nanos < nanosMin {
+		nanos = nanosMin
+	}
+	if units > 0 && nanos < 0 {
+		nanos = -nanos
+	}
+	if units < 0 && nanos > 0 {
+		nanos = -nanos
+	}
+	return &pb.Money{Units: units, Nanos: nanos}
+}
+
+// FuzzSum cross-checks Sum against the big.Rat reference implementation.
+func FuzzSum(f *testing.F) {
+	f.Add(int64(2), int32(200000000), int64(2), int32(900000000))
+	f.Fuzz(func(t *testing.T, lu int64, ln int32, ru int64, rn int32) {
+		l := clampMoney(lu, ln)
+		r := clampMoney(ru, rn)
+		got, err := Sum(l, r)
+		if err != nil {
+			return
+		}
+		want, ok := pbmoneytest.Sum(l, r, "")
+		if !ok {
+			return
+		}
+		if !AreEquals(got, want) {
+			t.Fatalf("Sum(%v, %v) = %v, want %v", l, r, got, want)
+		}
+	})
+}
+
+// FuzzMultiplyDivide cross-checks Multiply and Divide against the big.Rat
+// reference implementation.
+func FuzzMultiplyDivide(f *testing.F) {
+	f.Add(int64(2), int32(500000000), int64(3000000000))
+	f.Fuzz(func(t *testing.T, units int64, nanos int32, scalarNanos int64) {
+		m := clampMoney(units, nanos)
+		got, err := Multiply(m, scalarNanos)
+		if err != nil {
+			return
+		}
+		want, ok := pbmoneytest.Multiply(m, scalarNanos, "")
+		if !ok {
+			return
+		}
+		if !AreEquals(got, want) {
+			t.Fatalf("Multiply(%v, %d) = %v, want %v", m, scalarNanos, got, want)
+		}
+
+		if scalarNanos == 0 {
+			return
+		}
+		divided, err := Divide(m, scalarNanos)
+		if err != nil {
+			return
+		}
+		wantDivided, ok := pbmoneytest.Divide(m, scalarNanos, "")
+		if !ok {
+			return
+		}
+		if !AreEquals(divided, wantDivided) {
+			t.Fatalf("Divide(%v, %d) = %v, want %v", m, scalarNanos, divided, wantDivided)
+		}
+	})
+}
+
+// FuzzRoundTripString asserts Parse(Format(m)) == m for amounts already
+// quantized to the currency's own precision (USD cents here), which is the
+// only case where formatting and parsing shouldn't lose information.
+func FuzzRoundTripString(f *testing.F) {
+	f.Add(int64(1234), int32(560000000))
+	f.Fuzz(func(t *testing.T, units int64, nanos int32) {
+		m := clampMoney(units, nanos)
+		m.CurrencyCode = "USD"
+
+		quantized, err := Round(m, -2, RoundHalfUp)
+		if err == ErrOverflow {
+			return
+		}
+		if err != nil {
+			t.Fatalf("Round(%v) error: %v", m, err)
+		}
+
+		s, err := Format(quantized, FormatOptions{})
+		if err != nil {
+			t.Fatalf("Format(%v) error: %v", quantized, err)
+		}
+		got, err := Parse(s, "USD")
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", s, err)
+		}
+		if !AreEquals(got, quantized) {
+			t.Fatalf("Parse(Format(%v)) = %v, want %v", quantized, got, quantized)
+		}
+	})
+}
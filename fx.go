@@ -0,0 +1,108 @@
+package pbmoney
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+)
+
+// FXConverter converts an amount from its own currency to toCurrency.
+type FXConverter interface {
+	Convert(ctx context.Context, amount *pb.Money, toCurrency string) (*pb.Money, error)
+}
+
+// RateTable is an FXConverter backed by a static table of exchange rates,
+// triangulating through Base when a direct rate isn't given.
+//
+// Rates is keyed [from][to]; an entry Rates[Base][X] is read as "X units of
+// X per 1 unit of Base" and is used to triangulate any pair of currencies
+// that both have a rate against Base. A direct Rates[from][to] entry, if
+// present, takes priority over triangulation.
+type RateTable struct {
+	Base  string
+	Rates map[string]map[string]*big.Rat
+}
+
+// NewRateTable returns a RateTable that triangulates through base using
+// rates.
+func NewRateTable(base string, rates map[string]map[string]*big.Rat) *RateTable {
+	return &RateTable{Base: base, Rates: rates}
+}
+
+// Convert converts amount to toCurrency using t's rates, triangulating
+// through t.Base if there's no direct rate between the two currencies.
+func (t *RateTable) Convert(ctx context.Context, amount *pb.Money, toCurrency string) (*pb.Money, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !IsValid(amount) {
+		return nil, ErrInvalidValue
+	}
+	rate, err := t.rate(amount.GetCurrencyCode(), toCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	amountNanos, err := totalNanos(amount)
+	if err != nil {
+		return nil, err
+	}
+	total := big.NewInt(amountNanos)
+	total.Mul(total, rate.Num())
+	total.Quo(total, rate.Denom())
+	if !total.IsInt64() {
+		return nil, ErrOverflow
+	}
+	return fromTotalNanos(total.Int64(), toCurrency), nil
+}
+
+// rate returns the exchange rate from `from` to `to`: multiplying an amount
+// in `from` by this rate yields the equivalent amount in `to`.
+func (t *RateTable) rate(from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	if direct, ok := t.Rates[from]; ok {
+		if r, ok := direct[to]; ok {
+			return r, nil
+		}
+	}
+
+	fromPerBase, ok := t.baseRate(from)
+	if !ok {
+		return nil, fmt.Errorf("pbmoney: no FX rate from %s to %s", from, to)
+	}
+	toPerBase, ok := t.baseRate(to)
+	if !ok {
+		return nil, fmt.Errorf("pbmoney: no FX rate from %s to %s", from, to)
+	}
+	return new(big.Rat).Quo(toPerBase, fromPerBase), nil
+}
+
+// baseRate returns the units of currency per 1 unit of t.Base.
+func (t *RateTable) baseRate(currency string) (*big.Rat, bool) {
+	if currency == t.Base {
+		return big.NewRat(1, 1), true
+	}
+	r, ok := t.Rates[t.Base][currency]
+	return r, ok
+}
+
+// To collapses b into a single total in currency, converting every held
+// amount via conv.
+func (b *Bag) To(ctx context.Context, currency string, conv FXConverter) (*pb.Money, error) {
+	total := &pb.Money{CurrencyCode: currency}
+	for _, m := range b.Entries() {
+		converted, err := conv.Convert(ctx, m, currency)
+		if err != nil {
+			return nil, err
+		}
+		total, err = Sum(total, converted)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
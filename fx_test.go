@@ -0,0 +1,90 @@
+package pbmoney
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func usdEurGbpRateTable() *RateTable {
+	return NewRateTable("USD", map[string]map[string]*big.Rat{
+		"USD": {
+			"EUR": big.NewRat(9, 10), // 0.9 EUR per USD
+			"GBP": big.NewRat(4, 5),  // 0.8 GBP per USD
+		},
+	})
+}
+
+func TestRateTableConvertDirect(t *testing.T) {
+	table := usdEurGbpRateTable()
+	got, err := table.Convert(context.Background(), mmc(10, 0, "USD"), "EUR")
+	if err != nil {
+		t.Fatalf("Convert(10 USD, EUR): %v", err)
+	}
+	if want := mmc(9, 0, "EUR"); !AreEquals(got, want) {
+		t.Errorf("Convert(10 USD, EUR) = %v, want %v", got, want)
+	}
+}
+
+func TestRateTableConvertTriangulates(t *testing.T) {
+	table := usdEurGbpRateTable()
+	got, err := table.Convert(context.Background(), mmc(10, 0, "EUR"), "GBP")
+	if err != nil {
+		t.Fatalf("Convert(10 EUR, GBP): %v", err)
+	}
+	// rate(EUR, GBP) = baseRate(GBP)/baseRate(EUR) = 0.8/0.9 = 8/9; 10 EUR *
+	// 8/9 truncates to 8.888888888 GBP.
+	want := mmc(8, 888888888, "GBP")
+	if !AreEquals(got, want) {
+		t.Errorf("Convert(10 EUR, GBP) = %v, want %v", got, want)
+	}
+}
+
+func TestRateTableConvertSameCurrency(t *testing.T) {
+	table := usdEurGbpRateTable()
+	got, err := table.Convert(context.Background(), mmc(10, 0, "USD"), "USD")
+	if err != nil {
+		t.Fatalf("Convert(10 USD, USD): %v", err)
+	}
+	if want := mmc(10, 0, "USD"); !AreEquals(got, want) {
+		t.Errorf("Convert(10 USD, USD) = %v, want %v", got, want)
+	}
+}
+
+func TestRateTableConvertNoRate(t *testing.T) {
+	table := usdEurGbpRateTable()
+	if _, err := table.Convert(context.Background(), mmc(10, 0, "JPY"), "EUR"); err == nil {
+		t.Error("Convert(10 JPY, EUR): err = nil, want an error (no rate for JPY)")
+	}
+}
+
+func TestRateTableConvertCanceledContext(t *testing.T) {
+	table := usdEurGbpRateTable()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := table.Convert(ctx, mmc(10, 0, "USD"), "EUR"); err == nil {
+		t.Error("Convert with a canceled context: err = nil, want context.Canceled")
+	}
+}
+
+func TestBagTo(t *testing.T) {
+	table := usdEurGbpRateTable()
+	b := NewBag()
+	b, err := b.Add(mmc(10, 0, "USD"))
+	if err != nil {
+		t.Fatalf("Add(10 USD): %v", err)
+	}
+	b, err = b.Add(mmc(9, 0, "EUR"))
+	if err != nil {
+		t.Fatalf("Add(9 EUR): %v", err)
+	}
+
+	got, err := b.To(context.Background(), "USD", table)
+	if err != nil {
+		t.Fatalf("To(USD): %v", err)
+	}
+	// 10 USD direct + 9 EUR -> 10 USD via the inverse of the 0.9 EUR/USD rate = 20 USD total.
+	if want := mmc(20, 0, "USD"); !AreEquals(got, want) {
+		t.Errorf("To(USD) = %v, want %v", got, want)
+	}
+}
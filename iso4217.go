@@ -0,0 +1,76 @@
+package pbmoney
+
+// currencyExponents maps ISO 4217 currency codes to the number of digits
+// after the decimal point conventionally used for that currency (e.g. cents
+// for USD). Currencies not listed here default to 2 via CurrencyExponent.
+//
+// This is not an exhaustive ISO 4217 table; it covers the commonly
+// encountered majority-rule (2), zero-decimal (0), three-decimal (3) and
+// four-decimal (4, CLF) currencies. Extend it as new currencies are needed.
+var currencyExponents = map[string]int{
+	"BHD": 3,
+	"BIF": 0,
+	"BYR": 0,
+	"CLF": 4,
+	"CLP": 0,
+	"DJF": 0,
+	"GNF": 0,
+	"IQD": 3,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KMF": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"LYD": 3,
+	"OMR": 3,
+	"PYG": 0,
+	"RWF": 0,
+	"TND": 3,
+	"UGX": 0,
+	"UYI": 0,
+	"VND": 0,
+	"VUV": 0,
+	"XAF": 0,
+	"XOF": 0,
+	"XPF": 0,
+}
+
+// defaultCurrencyExponent is used for any currency code not present in
+// currencyExponents; it matches the large majority of active ISO 4217
+// currencies.
+const defaultCurrencyExponent = 2
+
+// CurrencyExponent returns the conventional number of fractional digits for
+// currencyCode per ISO 4217 (e.g. 0 for JPY, 2 for USD, 3 for BHD). Unknown
+// or empty currency codes default to 2.
+func CurrencyExponent(currencyCode string) int {
+	if exp, ok := currencyExponents[currencyCode]; ok {
+		return exp
+	}
+	return defaultCurrencyExponent
+}
+
+// currencySymbols maps a handful of common ISO 4217 currency codes to their
+// conventional display symbol. It is intentionally small; CurrencySymbol
+// falls back to the currency code itself for anything not listed here.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"CAD": "$",
+	"AUD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+}
+
+// CurrencySymbol returns the conventional display symbol for currencyCode,
+// or currencyCode itself if it isn't in the (small) known set.
+func CurrencySymbol(currencyCode string) string {
+	if symbol, ok := currencySymbols[currencyCode]; ok {
+		return symbol
+	}
+	return currencyCode
+}
@@ -2,10 +2,10 @@ package pbmoney
 
 import (
 	"errors"
-	"fmt"
+	"math"
+	"math/bits"
+
 	pb "google.golang.org/genproto/googleapis/type/money"
-	"strconv"
-	"strings"
 )
 
 const (
@@ -90,12 +90,16 @@ func Sum(l, r *pb.Money) (*pb.Money, error) {
 	units := l.GetUnits() + r.GetUnits()
 	nanos := l.GetNanos() + r.GetNanos()
 
-	if (units == 0 && nanos == 0) || (units > 0 && nanos >= 0) || (units < 0 && nanos <= 0) {
-		// same sign <units, nanos>
-		units += int64(nanos / nanosMod)
-		nanos = nanos % nanosMod
-	} else {
-		// different sign. nanos guaranteed to not to go over the limit
+	// Normalize nanos back into range first; carry and remainder always
+	// share nanos' sign, so this alone can't produce a units/nanos sign
+	// mismatch on its own.
+	units += int64(nanos / nanosMod)
+	nanos = nanos % nanosMod
+
+	// units and nanos can still disagree in sign here (e.g. units lands on
+	// 0 with a negative leftover nanos is fine, but a positive units with
+	// leftover negative nanos, or vice versa, needs a borrow/carry).
+	if (units > 0 && nanos < 0) || (units < 0 && nanos > 0) {
 		if units > 0 {
 			units--
 			nanos += nanosMod
@@ -133,91 +137,51 @@ func DivideInt(m *pb.Money, n uint32) *pb.Money {
 	return out
 }
 
-func MultipleFast(l, r *pb.Money) *pb.Money {
-	lr := unitsAndNanoPartToMicros(l.Units, l.Nanos)
-	rr := unitsAndNanoPartToMicros(r.Units, r.Nanos)
-	ln := lr * rr
-	return toGoogleMoney(ln, l.CurrencyCode)
+// ToInt returns the total amount as a signed count of nanos (units*1e9 +
+// nanos), or ErrOverflow if that doesn't fit in an int64.
+func ToInt(l *pb.Money) (int64, error) {
+	return totalNanos(l)
 }
 
-func DivideFast(l, r *pb.Money) *pb.Money {
-	lr := unitsAndNanoPartToMicros(l.Units, l.Nanos)
-	rr := unitsAndNanoPartToMicros(r.Units, r.Nanos)
-
-	ln := lr / rr
-	return toGoogleMoney(ln, l.CurrencyCode)
-}
+// totalNanos collapses units/nanos into a single signed count of nanos
+// (i.e. units*1e9 + nanos), returning ErrOverflow instead of wrapping if
+// units is large enough that units*1e9 (or the subsequent +nanos) doesn't
+// fit in an int64 — which a valid Money can easily trigger, since Units
+// alone can be as large as math.MaxInt64.
+func totalNanos(m *pb.Money) (int64, error) {
+	units := m.GetUnits()
+	if units == math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	neg := units < 0
+	abs := units
+	if neg {
+		abs = -abs
+	}
 
-func DivideFastInt(l *pb.Money, r int64) *pb.Money {
-	lr := unitsAndNanoPartToMicros(l.Units, l.Nanos)
+	hi, lo := bits.Mul64(uint64(abs), nanosMod)
+	if hi != 0 || lo > math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	total := int64(lo)
+	if neg {
+		total = -total
+	}
 
-	ln := lr / r
-	return toGoogleMoney(ln, l.CurrencyCode)
-}
-func MultipleFastInt(l *pb.Money, r int64) *pb.Money {
-	lr := unitsAndNanoPartToMicros(l.Units, l.Nanos)
-	ln := lr * r
-	return toGoogleMoney(ln, l.CurrencyCode)
+	nanos := int64(m.GetNanos())
+	sum := total + nanos
+	if (nanos > 0 && sum < total) || (nanos < 0 && sum > total) {
+		return 0, ErrOverflow
+	}
+	return sum, nil
 }
 
-func toGoogleMoney(valueMicros int64, currencyCode string) *pb.Money {
-	units, nanoPart := microsToUnitsAndNanoPart(valueMicros)
+// fromTotalNanos is the inverse of totalNanos: it splits a signed nano count
+// back into units/nanos with matching signs.
+func fromTotalNanos(total int64, currencyCode string) *pb.Money {
 	return &pb.Money{
+		Units:        total / nanosMod,
+		Nanos:        int32(total % nanosMod),
 		CurrencyCode: currencyCode,
-		Units:        units,
-		Nanos:        nanoPart,
-	}
-}
-
-func unitsAndMicroPartToMicros(units int64, micros int64) int64 {
-	return unitsToMicros(units) + micros
-}
-
-//func unitsAndNanoPartToMicros(units int64, nanos int32) int64 {
-//	return unitsToMicros(units) + int64(nanos/1000)
-//}
-
-func microsToUnitsAndMicroPart(micros int64) (int64, int64) {
-	return micros / 1000000, micros % 1000000
-}
-
-// edited
-func unitsAndNanoPartToMicros(units int64, nanos int32) int64 {
-	return (units*100 + int64(nanos/10000000))
-}
-
-//func microsToUnitsAndNanoPart(micros int64) (int64, int32) {
-//	return micros / 1000000, int32(micros%1000000) * 1000
-//}
-// edited
-func microsToUnitsAndNanoPart(micros int64) (int64, int32) {
-	return micros / 10000, int32(micros%10000) * 100000
-}
-
-func unitsToMicros(units int64) int64 {
-	return units * 1000000
-}
-
-func floatUnitsToMicros(floatUnits float64) int64 {
-	return int64(floatUnits * 1000000.0)
-}
-
-func microsToFloat(micros int64) float64 {
-	return float64(micros) / 1000000.0
-}
-
-func ToStringDollars(l *pb.Money) string {
-
-	nanos := strconv.Itoa(int(l.GetNanos()))
-	nanos = strings.TrimRight(nanos, "0")
-	if nanos == "" {
-		nanos = "00"
-	} else if len(nanos) == 1 {
-		nanos = nanos + "0"
 	}
-	return fmt.Sprintf("%d.%d", l.GetUnits(), nanos)
-}
-
-func ToInt(l *pb.Money) int64 {
-	return unitsAndNanoPartToMicros(l.GetUnits(), l.GetNanos())
 }
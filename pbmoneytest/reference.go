@@ -0,0 +1,98 @@
+// Package pbmoneytest provides a math/big.Rat-based reference
+// implementation of pbmoney's arithmetic, meant to be cross-checked against
+// the fixed-point implementation in property and fuzz tests. It trades
+// speed for being obviously correct: every operation goes through exact
+// rational arithmetic and is only quantized back to nanos at the very end.
+package pbmoneytest
+
+import (
+	"math/big"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+)
+
+const nanosPerUnit = 1000000000
+
+// Rat converts m to an exact rational value, in currency units (not nanos).
+func Rat(m *pb.Money) *big.Rat {
+	total := big.NewInt(m.GetUnits())
+	total.Mul(total, big.NewInt(nanosPerUnit))
+	total.Add(total, big.NewInt(int64(m.GetNanos())))
+	return new(big.Rat).SetFrac(total, big.NewInt(nanosPerUnit))
+}
+
+// FromRat quantizes r to nanos precision, truncating any finer remainder,
+// and builds a *pb.Money in currencyCode. It reports ok=false, rather than
+// silently wrapping, if the quantized total doesn't fit in an int64 count of
+// nanos.
+func FromRat(r *big.Rat, currencyCode string) (m *pb.Money, ok bool) {
+	scaled := new(big.Int).Mul(r.Num(), big.NewInt(nanosPerUnit))
+	scaled.Quo(scaled, r.Denom())
+	if !scaled.IsInt64() {
+		return nil, false
+	}
+	total := scaled.Int64()
+	return &pb.Money{
+		Units:        total / nanosPerUnit,
+		Nanos:        int32(total % nanosPerUnit),
+		CurrencyCode: currencyCode,
+	}, true
+}
+
+// Sum is the exact reference for pbmoney.Sum(l, r). ok is false if the exact
+// result overflows int64 nanos.
+func Sum(l, r *pb.Money, currencyCode string) (m *pb.Money, ok bool) {
+	return FromRat(new(big.Rat).Add(Rat(l), Rat(r)), currencyCode)
+}
+
+// Difference is the exact reference for pbmoney.Difference(l, r). ok is
+// false if the exact result overflows int64 nanos.
+func Difference(l, r *pb.Money, currencyCode string) (m *pb.Money, ok bool) {
+	return FromRat(new(big.Rat).Sub(Rat(l), Rat(r)), currencyCode)
+}
+
+// Multiply is the exact reference for pbmoney.Multiply(m, scalarNanos). ok
+// is false if the exact result overflows int64 nanos.
+func Multiply(m *pb.Money, scalarNanos int64, currencyCode string) (out *pb.Money, ok bool) {
+	scalar := new(big.Rat).SetFrac(big.NewInt(scalarNanos), big.NewInt(nanosPerUnit))
+	return FromRat(new(big.Rat).Mul(Rat(m), scalar), currencyCode)
+}
+
+// Divide is the exact reference for pbmoney.Divide(m, scalarNanos). ok is
+// false if the exact result overflows int64 nanos.
+func Divide(m *pb.Money, scalarNanos int64, currencyCode string) (out *pb.Money, ok bool) {
+	scalar := new(big.Rat).SetFrac(big.NewInt(scalarNanos), big.NewInt(nanosPerUnit))
+	return FromRat(new(big.Rat).Quo(Rat(m), scalar), currencyCode)
+}
+
+// Round is the exact reference for pbmoney.Round(m, exp, RoundHalfUp); exp
+// must be in [-9, 0].
+func Round(m *pb.Money, exp int32, currencyCode string) *pb.Money {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil)
+	roundedUnits := roundHalfUp(Rat(m), scale)
+	nanosPerRoundedUnit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(9+exp)), nil)
+	total := new(big.Int).Mul(roundedUnits, nanosPerRoundedUnit)
+	return &pb.Money{
+		Units:        new(big.Int).Quo(total, big.NewInt(nanosPerUnit)).Int64(),
+		Nanos:        int32(new(big.Int).Rem(total, big.NewInt(nanosPerUnit)).Int64()),
+		CurrencyCode: currencyCode,
+	}
+}
+
+// roundHalfUp returns round(v*scale), rounding exact halves away from zero.
+func roundHalfUp(v *big.Rat, scale *big.Int) *big.Int {
+	num := new(big.Int).Mul(v.Num(), scale)
+	den := v.Denom()
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	twiceR := new(big.Int).Abs(r)
+	twiceR.Lsh(twiceR, 1)
+	if twiceR.Cmp(den) >= 0 {
+		if num.Sign() >= 0 {
+			q.Add(q, big.NewInt(1))
+		} else {
+			q.Sub(q, big.NewInt(1))
+		}
+	}
+	return q
+}
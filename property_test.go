@@ -0,0 +1,145 @@
+package pbmoney
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+// TestSumCommutative asserts Sum(l, r) == Sum(r, l) for every valid pair.
+func TestSumCommutative(t *testing.T) {
+	f := func(lu int64, ln int32, ru int64, rn int32) bool {
+		l, r := clampMoney(lu, ln), clampMoney(ru, rn)
+		l.CurrencyCode, r.CurrencyCode = "USD", "USD"
+		a, errA := Sum(l, r)
+		b, errB := Sum(r, l)
+		if errA != nil || errB != nil {
+			return errA != nil && errB != nil
+		}
+		return AreEquals(a, b)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestSumWithNegateIsZero asserts Sum(m, Negate(m)) == 0 for every valid m.
+func TestSumWithNegateIsZero(t *testing.T) {
+	f := func(units int64, nanos int32) bool {
+		m := clampMoney(units, nanos)
+		m.CurrencyCode = "USD"
+		sum, err := Sum(m, Negate(m))
+		if err != nil {
+			return false
+		}
+		return IsZero(sum)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestMultiplyIntMatchesMultipleFastInt asserts the slow repeated-addition
+// MultiplyInt agrees with the 128-bit-math MultipleFastInt for every valid
+// (units, nanos, n).
+func TestMultiplyIntMatchesMultipleFastInt(t *testing.T) {
+	f := func(units int16, nanos int32, n uint8) bool {
+		if n == 0 {
+			n = 1
+		}
+		m := clampMoney(int64(units), nanos)
+		m.CurrencyCode = "USD"
+		slow := MultiplyInt(m, uint32(n))
+		fast := MultipleFastInt(m, int64(n))
+		return AreEquals(slow, fast)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 500}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestAllocateSumsToTotal asserts that summing Allocate's output always
+// reproduces the original amount exactly, across the full int64/int32
+// range (including magnitudes where total*ratio doesn't fit in an int64,
+// which Allocate handles through 128-bit math; amounts whose nano total
+// itself overflows are reported as ErrOverflow and skipped here).
+func TestAllocateSumsToTotal(t *testing.T) {
+	f := func(units int64, nanos int32, r1, r2, r3 uint32) bool {
+		if r1 == 0 && r2 == 0 && r3 == 0 {
+			r1 = 1
+		}
+		m := clampMoney(units, nanos)
+		m.CurrencyCode = "USD"
+		parts, err := Allocate(m, []int64{int64(r1), int64(r2), int64(r3)})
+		if err != nil {
+			return true
+		}
+		sum := mmc(0, 0, "USD")
+		for _, p := range parts {
+			sum, err = Sum(sum, p)
+			if err != nil {
+				return true
+			}
+		}
+		return AreEquals(sum, m)
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestAllocateLargeRatioDoesNotWrap regression-tests a previous version of
+// Allocate that multiplied total*ratio as a plain int64: for this input the
+// multiply silently wrapped, leftover became a bogus ~9e18, and the
+// one-nano-at-a-time distribution loop was left to walk through it instead
+// of terminating. Routed through the same 128-bit-safe math as mulDiv,
+// Allocate must return promptly with a split that sums back to m.
+func TestAllocateLargeRatioDoesNotWrap(t *testing.T) {
+	m := mmc(9000000000, 0, "USD")
+	parts, err := Allocate(m, []int64{1000000, 1})
+	if err != nil {
+		t.Fatalf("Allocate(%v, [1000000, 1]) error = %v, want nil", m, err)
+	}
+	sum := mmc(0, 0, "USD")
+	for _, p := range parts {
+		sum, err = Sum(sum, p)
+		if err != nil {
+			t.Fatalf("Sum(%v): %v", p, err)
+		}
+	}
+	if !AreEquals(sum, m) {
+		t.Fatalf("Allocate(%v, [1000000, 1]) parts sum to %v, want %v", m, sum, m)
+	}
+}
+
+// TestAbsOverflowAtMinInt64 regression-tests Abs on the one amount whose
+// totalNanos is math.MinInt64, which a naive "-total" silently wraps back to
+// itself instead of reporting.
+func TestAbsOverflowAtMinInt64(t *testing.T) {
+	m := mmc(-9223372036, -854775808, "USD")
+	if _, err := Abs(m); err != ErrOverflow {
+		t.Fatalf("Abs(%v) error = %v, want ErrOverflow", m, err)
+	}
+}
+
+// TestRoundOverflowNearMaxInt64 regression-tests Round rounding a
+// near-math.MaxInt64 amount up past what fits in an int64 nano count, which
+// a plain "quotient*scale" silently wraps to a negative result.
+func TestRoundOverflowNearMaxInt64(t *testing.T) {
+	m := mmc(9223372036, 854775807, "USD")
+	if _, err := Round(m, 0, RoundHalfUp); err != ErrOverflow {
+		t.Fatalf("Round(%v, 0, RoundHalfUp) error = %v, want ErrOverflow", m, err)
+	}
+}
+
+// TestMultiplyFloatOverflowAtHugeScalar regression-tests MultiplyFloat with
+// a scalar large enough that scalar*1e9 doesn't fit in an int64. A plain
+// int64(math.Round(...)) conversion of a float that large is
+// implementation-defined in Go and, on this platform, lands on
+// math.MinInt64 -- which mulDiv's abs64/unsigned cast then reinterprets as
+// the valid magnitude 2^63, masking the overflow entirely.
+func TestMultiplyFloatOverflowAtHugeScalar(t *testing.T) {
+	m := mmc(0, 1, "USD")
+	if _, err := MultiplyFloat(m, 1e20); err != ErrOverflow {
+		t.Fatalf("MultiplyFloat(%v, 1e20) error = %v, want ErrOverflow", m, err)
+	}
+}
@@ -0,0 +1,293 @@
+package pbmoney
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+)
+
+// JSONMode selects how Money encodes itself as JSON.
+type JSONMode int
+
+const (
+	// jsonModeUnset means "use DefaultJSONMode"; it's the zero value so that
+	// a Money left at its default JSONMode defers to the package setting.
+	jsonModeUnset JSONMode = iota
+	// JSONNumeric encodes as a decimal string, e.g. "1234.56". It carries no
+	// currency, so UnmarshalJSON needs the target Money's CurrencyCode set
+	// beforehand to know how many fraction digits to expect.
+	JSONNumeric
+	// JSONObject encodes as {"currencyCode":"USD","units":1234,"nanos":560000000}.
+	JSONObject
+	// JSONExtended encodes as [1234.56,"USD","$1,234.56"]: a float
+	// approximation, the currency code, and a symbol-formatted string.
+	JSONExtended
+)
+
+// DefaultJSONMode is the JSONMode used by any Money whose own JSONMode is
+// left unset.
+var DefaultJSONMode = JSONObject
+
+// SQLMode selects how Money reads/writes itself through database/sql.
+type SQLMode int
+
+const (
+	sqlModeUnset SQLMode = iota
+	// SQLNumeric round-trips through a NUMERIC(20,9) column holding just the
+	// decimal amount. It does not carry the currency code: pair it with a
+	// separate currency column in your schema, and set CurrencyCode on the
+	// Money yourself (Scan can't see sibling columns).
+	SQLNumeric
+	// SQLComposite round-trips through an (amount_nanos BIGINT, currency
+	// CHAR(3)) pair, encoded as the single string "amount_nanos,currency"
+	// since database/sql/driver.Value can't itself be a composite.
+	SQLComposite
+)
+
+// DefaultSQLMode is the SQLMode used by any Money whose own SQLMode is left
+// unset.
+var DefaultSQLMode = SQLNumeric
+
+// Money wraps a *pb.Money to add JSON, SQL and text marshalling. The zero
+// value is a nil amount; JSONMode/SQLMode let a particular field opt out of
+// the package-level defaults.
+type Money struct {
+	*pb.Money
+	JSONMode JSONMode
+	SQLMode  SQLMode
+}
+
+type moneyObjectJSON struct {
+	CurrencyCode string `json:"currencyCode"`
+	Units        int64  `json:"units"`
+	Nanos        int32  `json:"nanos"`
+}
+
+// MarshalJSON implements json.Marshaler using m.JSONMode (or DefaultJSONMode
+// if unset).
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.Money == nil {
+		return []byte("null"), nil
+	}
+	if !IsValid(m.Money) {
+		return nil, ErrInvalidValue
+	}
+
+	mode := m.JSONMode
+	if mode == jsonModeUnset {
+		mode = DefaultJSONMode
+	}
+
+	switch mode {
+	case JSONObject:
+		return json.Marshal(moneyObjectJSON{
+			CurrencyCode: m.GetCurrencyCode(),
+			Units:        m.GetUnits(),
+			Nanos:        m.GetNanos(),
+		})
+	case JSONExtended:
+		numeric, err := Format(m.Money, FormatOptions{})
+		if err != nil {
+			return nil, err
+		}
+		value, err := strconv.ParseFloat(numeric, 64)
+		if err != nil {
+			return nil, err
+		}
+		symbolic, err := Format(m.Money, FormatOptions{
+			ThousandsSeparator: ",",
+			CurrencySymbol:     CurrencySymbol(m.GetCurrencyCode()),
+			SymbolPlacement:    SymbolPrefix,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal([]interface{}{value, m.GetCurrencyCode(), symbolic})
+	default: // JSONNumeric
+		s, err := Format(m.Money, FormatOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(s)
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler, detecting which of the three
+// JSONMode encodings is present from the data's shape.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		m.Money = nil
+		return nil
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("pbmoney: empty JSON for Money")
+	}
+
+	switch data[0] {
+	case '{':
+		var obj moneyObjectJSON
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		parsed := &pb.Money{CurrencyCode: obj.CurrencyCode, Units: obj.Units, Nanos: obj.Nanos}
+		if !IsValid(parsed) {
+			return ErrInvalidValue
+		}
+		m.Money = parsed
+		return nil
+
+	case '[':
+		var fields []json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		if len(fields) < 2 {
+			return fmt.Errorf("pbmoney: JSONExtended array needs at least [amount, currency]")
+		}
+		var value float64
+		if err := json.Unmarshal(fields[0], &value); err != nil {
+			return err
+		}
+		var currency string
+		if err := json.Unmarshal(fields[1], &currency); err != nil {
+			return err
+		}
+		parsed, err := Parse(strconv.FormatFloat(value, 'f', -1, 64), currency, RoundHalfUp)
+		if err != nil {
+			return err
+		}
+		if !IsValid(parsed) {
+			return ErrInvalidValue
+		}
+		m.Money = parsed
+		return nil
+
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		currency := m.GetCurrencyCode()
+		parsed, err := Parse(s, currency)
+		if err != nil {
+			return err
+		}
+		m.Money = parsed
+		return nil
+
+	default:
+		return fmt.Errorf("pbmoney: unrecognized JSON for Money: %s", data)
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler as a plain decimal amount
+// (no currency symbol or thousands separator), the same shape as
+// JSONNumeric.
+func (m Money) MarshalText() ([]byte, error) {
+	if m.Money == nil {
+		return nil, nil
+	}
+	s, err := Format(m.Money, FormatOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Since the text carries
+// no currency, m's existing CurrencyCode (if any) is used to determine the
+// expected number of fraction digits.
+func (m *Money) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text), m.GetCurrencyCode(), RoundHalfUp)
+	if err != nil {
+		return err
+	}
+	m.Money = parsed
+	return nil
+}
+
+// Value implements driver.Valuer using m.SQLMode (or DefaultSQLMode if
+// unset).
+func (m Money) Value() (driver.Value, error) {
+	if m.Money == nil {
+		return nil, nil
+	}
+	if !IsValid(m.Money) {
+		return nil, ErrInvalidValue
+	}
+
+	mode := m.SQLMode
+	if mode == sqlModeUnset {
+		mode = DefaultSQLMode
+	}
+
+	if mode == SQLComposite {
+		nanos, err := totalNanos(m.Money)
+		if err != nil {
+			return nil, err
+		}
+		return fmt.Sprintf("%d,%s", nanos, m.GetCurrencyCode()), nil
+	}
+	nanosExp := 9
+	return Format(m.Money, FormatOptions{MinFractionDigits: &nanosExp, MaxFractionDigits: &nanosExp})
+}
+
+// Scan implements sql.Scanner using m.SQLMode (or DefaultSQLMode if unset).
+func (m *Money) Scan(src interface{}) error {
+	if src == nil {
+		m.Money = nil
+		return nil
+	}
+	s, err := scanString(src)
+	if err != nil {
+		return err
+	}
+
+	mode := m.SQLMode
+	if mode == sqlModeUnset {
+		mode = DefaultSQLMode
+	}
+
+	if mode == SQLComposite {
+		parts := strings.SplitN(s, ",", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("pbmoney: invalid SQLComposite value %q", s)
+		}
+		nanos, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("pbmoney: invalid SQLComposite value %q: %w", s, err)
+		}
+		m.Money = fromTotalNanos(nanos, parts[1])
+		return nil
+	}
+
+	// SQLNumeric's Value always writes 9 fraction digits (NUMERIC(20,9)), so
+	// Scan must parse at that same precision rather than quantizing down to
+	// the currency's usual exponent, or every round trip would silently lose
+	// precision.
+	parsed, err := parseAtExponent(s, m.GetCurrencyCode(), 9, RoundHalfUp)
+	if err != nil {
+		return err
+	}
+	m.Money = parsed
+	return nil
+}
+
+// scanString coerces a database/sql source value (typically string or
+// []byte for a NUMERIC/text column) into a string.
+func scanString(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("pbmoney: cannot scan %T into Money", src)
+	}
+}
@@ -0,0 +1,166 @@
+package pbmoney
+
+import (
+	"encoding/json"
+	"testing"
+
+	pb "google.golang.org/genproto/googleapis/type/money"
+)
+
+func TestMoneyJSONNumericRoundTrip(t *testing.T) {
+	in := Money{Money: mmc(1234, 560000000, "USD"), JSONMode: JSONNumeric}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if want := `"1234.56"`; string(data) != want {
+		t.Fatalf("Marshal = %s, want %s", data, want)
+	}
+
+	var out Money
+	out.Money = &pb.Money{CurrencyCode: "USD"}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", data, err)
+	}
+	if !AreEquals(out.Money, in.Money) {
+		t.Errorf("round trip = %v, want %v", out.Money, in.Money)
+	}
+}
+
+func TestMoneyJSONObjectRoundTrip(t *testing.T) {
+	in := Money{Money: mmc(1234, 560000000, "USD"), JSONMode: JSONObject}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out Money
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", data, err)
+	}
+	if !AreEquals(out.Money, in.Money) {
+		t.Errorf("round trip = %v, want %v", out.Money, in.Money)
+	}
+}
+
+func TestMoneyJSONExtendedRoundTrip(t *testing.T) {
+	in := Money{Money: mmc(1234, 560000000, "USD"), JSONMode: JSONExtended}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out Money
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal(%s): %v", data, err)
+	}
+	if !AreEquals(out.Money, in.Money) {
+		t.Errorf("round trip = %v, want %v", out.Money, in.Money)
+	}
+}
+
+func TestMoneyUnmarshalJSONNull(t *testing.T) {
+	var out Money
+	out.Money = mmc(1, 0, "USD")
+	if err := json.Unmarshal([]byte("null"), &out); err != nil {
+		t.Fatalf("Unmarshal(null): %v", err)
+	}
+	if out.Money != nil {
+		t.Errorf("Unmarshal(null): Money = %v, want nil", out.Money)
+	}
+}
+
+func TestMoneyUnmarshalJSONRejectsSignMismatch(t *testing.T) {
+	var out Money
+	err := json.Unmarshal([]byte(`{"currencyCode":"USD","units":1,"nanos":-500000000}`), &out)
+	if err != ErrInvalidValue {
+		t.Fatalf("Unmarshal(sign-mismatched object) = %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestMoneyMarshalJSONNil(t *testing.T) {
+	var m Money
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal(nil Money): %v", err)
+	}
+	if string(data) != "null" {
+		t.Errorf("Marshal(nil Money) = %s, want null", data)
+	}
+}
+
+func TestMoneyTextRoundTrip(t *testing.T) {
+	in := Money{Money: mmc(1234, 560000000, "USD")}
+	data, err := in.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: %v", err)
+	}
+	if want := "1234.56"; string(data) != want {
+		t.Fatalf("MarshalText = %s, want %s", data, want)
+	}
+
+	var out Money
+	out.Money = &pb.Money{CurrencyCode: "USD"}
+	if err := out.UnmarshalText(data); err != nil {
+		t.Fatalf("UnmarshalText(%s): %v", data, err)
+	}
+	if !AreEquals(out.Money, in.Money) {
+		t.Errorf("round trip = %v, want %v", out.Money, in.Money)
+	}
+}
+
+func TestMoneySQLNumericRoundTrip(t *testing.T) {
+	in := Money{Money: mmc(1234, 560000000, "USD"), SQLMode: SQLNumeric}
+	value, err := in.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	var out Money
+	out.Money = &pb.Money{CurrencyCode: "USD"}
+	out.SQLMode = SQLNumeric
+	if err := out.Scan(value); err != nil {
+		t.Fatalf("Scan(%v): %v", value, err)
+	}
+	if !AreEquals(out.Money, in.Money) {
+		t.Errorf("round trip = %v, want %v", out.Money, in.Money)
+	}
+}
+
+func TestMoneySQLCompositeRoundTrip(t *testing.T) {
+	in := Money{Money: mmc(1234, 560000000, "USD"), SQLMode: SQLComposite}
+	value, err := in.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	var out Money
+	out.SQLMode = SQLComposite
+	if err := out.Scan(value); err != nil {
+		t.Fatalf("Scan(%v): %v", value, err)
+	}
+	if !AreEquals(out.Money, in.Money) {
+		t.Errorf("round trip = %v, want %v", out.Money, in.Money)
+	}
+}
+
+func TestMoneyValueNil(t *testing.T) {
+	var m Money
+	value, err := m.Value()
+	if err != nil {
+		t.Fatalf("Value(nil Money): %v", err)
+	}
+	if value != nil {
+		t.Errorf("Value(nil Money) = %v, want nil", value)
+	}
+}
+
+func TestMoneyScanNil(t *testing.T) {
+	m := Money{Money: mmc(1, 0, "USD")}
+	if err := m.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil): %v", err)
+	}
+	if m.Money != nil {
+		t.Errorf("Scan(nil): Money = %v, want nil", m.Money)
+	}
+}